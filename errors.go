@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// HTTPError represents a non-2xx response from an OTLP/HTTP endpoint.
+// RetryAfter is populated from the Retry-After header when present.
+type HTTPError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	if e.Body != "" {
+		return fmt.Sprintf("received status code %d: %s", e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("received status code %d", e.StatusCode)
+}
+
+// Retryable reports whether the retry layer should retry the request that
+// produced this error. Per the OTLP spec, 429 and 502/503/504 are
+// retryable; all other 4xx responses are permanent failures.
+func (e *HTTPError) Retryable() bool {
+	switch e.StatusCode {
+	case 429, 502, 503, 504:
+		return true
+	default:
+		return false
+	}
+}
+
+// GRPCError represents a non-OK status returned by an OTLP/gRPC export call.
+type GRPCError struct {
+	Code    codes.Code
+	Message string
+}
+
+func (e *GRPCError) Error() string {
+	return fmt.Sprintf("rpc error: code = %s desc = %s", e.Code, e.Message)
+}
+
+// Retryable reports whether the retry layer should retry the request that
+// produced this error. Unavailable, DeadlineExceeded, and ResourceExhausted
+// are transient conditions worth retrying; every other status (bad
+// endpoint, auth failure, Unimplemented, InvalidArgument, ...) is permanent.
+func (e *GRPCError) Retryable() bool {
+	switch e.Code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// PartialSuccessError indicates the collector accepted the export call but
+// rejected some of the log records it carried. It is never retried: the
+// rejected records are gone and resending the whole batch would just
+// duplicate the accepted ones.
+type PartialSuccessError struct {
+	RejectedLogRecords int64
+	ErrorMessage       string
+}
+
+func (e *PartialSuccessError) Error() string {
+	return fmt.Sprintf("collector rejected %d log records: %s", e.RejectedLogRecords, e.ErrorMessage)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Protocol selects the wire format and transport used to export logs,
+// mirroring the values accepted by the standard OTEL_EXPORTER_OTLP_PROTOCOL
+// environment variable.
+type Protocol string
+
+const (
+	// ProtocolHTTPJSON posts OTLP logs as JSON to the /v1/logs endpoint.
+	ProtocolHTTPJSON Protocol = "otlp/http/json"
+	// ProtocolHTTPProtobuf posts OTLP logs as protobuf to the /v1/logs endpoint.
+	ProtocolHTTPProtobuf Protocol = "otlp/http/protobuf"
+	// ProtocolGRPC exports OTLP logs over the gRPC LogsService.
+	ProtocolGRPC Protocol = "otlp/grpc"
+)
+
+// Exporter sends log records, grouped by the resource and instrumentation
+// scope they originated from, to an OTLP-compatible backend.
+type Exporter interface {
+	ExportLogs(ctx context.Context, groups []ResourceLogsGroup) error
+}
+
+// newExporter builds the Exporter for the requested protocol. An empty
+// protocol defaults to ProtocolHTTPJSON for backwards compatibility.
+func newExporter(protocol Protocol, endpoint string) (Exporter, error) {
+	switch protocol {
+	case ProtocolHTTPJSON, "":
+		return NewHTTPJSONExporter(endpoint, &http.Client{Timeout: 10 * time.Second}), nil
+	case ProtocolGRPC:
+		return NewGRPCExporter(endpoint)
+	case ProtocolHTTPProtobuf:
+		return nil, fmt.Errorf("otel-log-sender: protocol %q is not yet supported", protocol)
+	default:
+		return nil, fmt.Errorf("otel-log-sender: unknown protocol %q", protocol)
+	}
+}
+
+// HTTPJSONExporter exports logs as OTLP/HTTP with a JSON-encoded body, the
+// format the collector's /v1/logs endpoint accepts by default.
+type HTTPJSONExporter struct {
+	client   *http.Client
+	endpoint string
+}
+
+// NewHTTPJSONExporter returns an Exporter that POSTs JSON-encoded
+// ExportLogsServiceRequest payloads to endpoint+"/v1/logs".
+func NewHTTPJSONExporter(endpoint string, client *http.Client) *HTTPJSONExporter {
+	return &HTTPJSONExporter{
+		client:   client,
+		endpoint: endpoint,
+	}
+}
+
+func (e *HTTPJSONExporter) ExportLogs(ctx context.Context, groups []ResourceLogsGroup) error {
+	resourceLogs := make([]ResourceLogs, 0, len(groups))
+	for _, g := range groups {
+		scopeLogs := make([]*ScopeLogs, 0, len(g.Scopes))
+		for _, s := range g.Scopes {
+			scopeLogs = append(scopeLogs, &ScopeLogs{Scope: s.Scope, LogRecords: s.Records})
+		}
+		resourceLogs = append(resourceLogs, ResourceLogs{Resource: g.Resource, ScopeLogs: scopeLogs})
+	}
+	payload := map[string]interface{}{"resourceLogs": resourceLogs}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling log batch: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", e.endpoint+"/v1/logs", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending logs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var responseBody map[string]interface{}
+		body := ""
+		if err := json.NewDecoder(resp.Body).Decode(&responseBody); err == nil {
+			body = fmt.Sprintf("%+v", responseBody)
+		}
+		return &HTTPError{
+			StatusCode: resp.StatusCode,
+			Body:       body,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	var result struct {
+		PartialSuccess *struct {
+			RejectedLogRecords int64  `json:"rejectedLogRecords"`
+			ErrorMessage       string `json:"errorMessage"`
+		} `json:"partialSuccess"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil && err != io.EOF {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	if result.PartialSuccess != nil && result.PartialSuccess.RejectedLogRecords > 0 {
+		return &PartialSuccessError{
+			RejectedLogRecords: result.PartialSuccess.RejectedLogRecords,
+			ErrorMessage:       result.PartialSuccess.ErrorMessage,
+		}
+	}
+
+	return nil
+}
+
+// parseRetryAfter parses the seconds form of a Retry-After header. The
+// HTTP-date form is uncommon from OTLP collectors and is not supported.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
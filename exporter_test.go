@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestNewExporterSelectsByProtocol(t *testing.T) {
+	if _, err := newExporter(ProtocolHTTPJSON, "http://localhost:4318"); err != nil {
+		t.Fatalf("ProtocolHTTPJSON: unexpected error: %v", err)
+	}
+	if _, err := newExporter("", "http://localhost:4318"); err != nil {
+		t.Fatalf("empty protocol: expected default to ProtocolHTTPJSON, got error: %v", err)
+	}
+	if _, err := newExporter(ProtocolGRPC, "localhost:4317"); err != nil {
+		t.Fatalf("ProtocolGRPC: unexpected error: %v", err)
+	}
+}
+
+func TestNewExporterRejectsUnsupportedOrUnknownProtocol(t *testing.T) {
+	if _, err := newExporter(ProtocolHTTPProtobuf, "http://localhost:4318"); err == nil {
+		t.Fatal("expected ProtocolHTTPProtobuf to be rejected as not yet supported")
+	}
+	if _, err := newExporter("otlp/carrier-pigeon", "http://localhost:4318"); err == nil {
+		t.Fatal("expected an unknown protocol to return an error")
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ScopeLogsGroup batches log records sharing one instrumentation Scope.
+type ScopeLogsGroup struct {
+	Scope   Scope
+	Records []*LogRecord
+}
+
+// ResourceLogsGroup batches ScopeLogsGroups sharing one Resource.
+type ResourceLogsGroup struct {
+	Resource Resource
+	Scopes   []ScopeLogsGroup
+}
+
+// groupByResourceAndScope buckets queued records into one ResourceLogsGroup
+// per unique resource (identified by hashResource) containing one
+// ScopeLogsGroup per unique scope (identified by name+version). Without
+// this, a batch spanning more than one resource or scope — as happens once
+// per-record resources or scopes are in play — would collapse into a
+// single, misattributed ResourceLogs/ScopeLogs pair.
+func groupByResourceAndScope(items []queuedRecord) []ResourceLogsGroup {
+	type scopeBucket struct {
+		scope   Scope
+		records []*LogRecord
+	}
+	type resourceBucket struct {
+		resource    Resource
+		scopeOrder  []string
+		scopesByKey map[string]*scopeBucket
+	}
+
+	var resourceOrder []string
+	resourcesByKey := make(map[string]*resourceBucket)
+
+	for _, item := range items {
+		rKey := hashResource(item.resource)
+		rb, ok := resourcesByKey[rKey]
+		if !ok {
+			rb = &resourceBucket{resource: item.resource, scopesByKey: make(map[string]*scopeBucket)}
+			resourcesByKey[rKey] = rb
+			resourceOrder = append(resourceOrder, rKey)
+		}
+
+		sKey := item.scope.Name + "\x00" + item.scope.Version
+		sb, ok := rb.scopesByKey[sKey]
+		if !ok {
+			sb = &scopeBucket{scope: item.scope}
+			rb.scopesByKey[sKey] = sb
+			rb.scopeOrder = append(rb.scopeOrder, sKey)
+		}
+
+		record := item.record
+		sb.records = append(sb.records, &record)
+	}
+
+	groups := make([]ResourceLogsGroup, 0, len(resourceOrder))
+	for _, rKey := range resourceOrder {
+		rb := resourcesByKey[rKey]
+		scopes := make([]ScopeLogsGroup, 0, len(rb.scopeOrder))
+		for _, sKey := range rb.scopeOrder {
+			sb := rb.scopesByKey[sKey]
+			scopes = append(scopes, ScopeLogsGroup{Scope: sb.scope, Records: sb.records})
+		}
+		groups = append(groups, ResourceLogsGroup{Resource: rb.resource, Scopes: scopes})
+	}
+
+	return groups
+}
+
+// hashResource computes a stable identity for a Resource by sorting its
+// attributes by key and hashing "key=stringified-value" pairs, so
+// semantically equal resources collapse into the same group regardless of
+// attribute order.
+func hashResource(r Resource) string {
+	attrs := make([]KeyValue, len(r.Attributes))
+	copy(attrs, r.Attributes)
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+
+	h := fnv.New64a()
+	for _, kv := range attrs {
+		h.Write([]byte(kv.Key))
+		h.Write([]byte("="))
+		h.Write([]byte(stringifyAttributeValue(kv.Value)))
+		h.Write([]byte(";"))
+	}
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+func stringifyAttributeValue(v AttributeValue) string {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return strconv.FormatBool(*v.BoolValue)
+	case v.IntValue != nil:
+		return strconv.FormatInt(*v.IntValue, 10)
+	case v.DoubleValue != nil:
+		return strconv.FormatFloat(*v.DoubleValue, 'g', -1, 64)
+	case v.BytesValue != nil:
+		return string(v.BytesValue)
+	case v.ArrayValue != nil:
+		parts := make([]string, len(v.ArrayValue.Values))
+		for i, av := range v.ArrayValue.Values {
+			parts[i] = stringifyAttributeValue(av)
+		}
+		return strings.Join(parts, ",")
+	case v.KvlistValue != nil:
+		parts := make([]string, len(v.KvlistValue.Values))
+		for i, kv := range v.KvlistValue.Values {
+			parts[i] = kv.Key + "=" + stringifyAttributeValue(kv.Value)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import "testing"
+
+func strAttr(s string) AttributeValue {
+	v := s
+	return AttributeValue{StringValue: &v}
+}
+
+func TestHashResourceOrderIndependent(t *testing.T) {
+	a := Resource{Attributes: []KeyValue{
+		{Key: "service.name", Value: strAttr("svc")},
+		{Key: "host.name", Value: strAttr("box1")},
+	}}
+	b := Resource{Attributes: []KeyValue{
+		{Key: "host.name", Value: strAttr("box1")},
+		{Key: "service.name", Value: strAttr("svc")},
+	}}
+
+	if hashResource(a) != hashResource(b) {
+		t.Fatalf("expected semantically equal resources (different attribute order) to hash the same: %q vs %q", hashResource(a), hashResource(b))
+	}
+}
+
+func TestHashResourceDistinguishesDifferentValues(t *testing.T) {
+	a := Resource{Attributes: []KeyValue{{Key: "service.name", Value: strAttr("svc-a")}}}
+	b := Resource{Attributes: []KeyValue{{Key: "service.name", Value: strAttr("svc-b")}}}
+
+	if hashResource(a) == hashResource(b) {
+		t.Fatalf("expected distinct resource attribute values to hash differently, both got %q", hashResource(a))
+	}
+}
+
+func TestGroupByResourceAndScope(t *testing.T) {
+	resA := Resource{Attributes: []KeyValue{{Key: "service.name", Value: strAttr("a")}}}
+	resB := Resource{Attributes: []KeyValue{{Key: "service.name", Value: strAttr("b")}}}
+	scope1 := Scope{Name: "scope-1", Version: "1.0"}
+	scope2 := Scope{Name: "scope-2", Version: "1.0"}
+
+	items := []queuedRecord{
+		{record: LogRecord{Body: AttributeValue{StringValue: strPtr("a1")}}, resource: resA, scope: scope1},
+		{record: LogRecord{Body: AttributeValue{StringValue: strPtr("b1")}}, resource: resB, scope: scope1},
+		{record: LogRecord{Body: AttributeValue{StringValue: strPtr("a2")}}, resource: resA, scope: scope1},
+		{record: LogRecord{Body: AttributeValue{StringValue: strPtr("a3")}}, resource: resA, scope: scope2},
+	}
+
+	groups := groupByResourceAndScope(items)
+
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 resource groups, got %d", len(groups))
+	}
+
+	byResourceKey := make(map[string]ResourceLogsGroup, len(groups))
+	for _, g := range groups {
+		byResourceKey[hashResource(g.Resource)] = g
+	}
+
+	groupA, ok := byResourceKey[hashResource(resA)]
+	if !ok {
+		t.Fatalf("missing group for resource A")
+	}
+	if len(groupA.Scopes) != 2 {
+		t.Fatalf("expected resource A to have 2 scopes, got %d", len(groupA.Scopes))
+	}
+	for _, s := range groupA.Scopes {
+		if s.Scope == scope1 && len(s.Records) != 2 {
+			t.Fatalf("expected 2 records under scope1 for resource A, got %d", len(s.Records))
+		}
+		if s.Scope == scope2 && len(s.Records) != 1 {
+			t.Fatalf("expected 1 record under scope2 for resource A, got %d", len(s.Records))
+		}
+	}
+
+	groupB, ok := byResourceKey[hashResource(resB)]
+	if !ok {
+		t.Fatalf("missing group for resource B")
+	}
+	if len(groupB.Scopes) != 1 || len(groupB.Scopes[0].Records) != 1 {
+		t.Fatalf("expected resource B to have 1 scope with 1 record, got %+v", groupB.Scopes)
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCExporter exports logs over the OTLP/gRPC LogsService, as implemented
+// by go.opentelemetry.io/proto/otlp/collector/logs/v1.
+type GRPCExporter struct {
+	conn   *grpc.ClientConn
+	client collogspb.LogsServiceClient
+}
+
+// NewGRPCExporter dials endpoint and returns an Exporter that speaks
+// OTLP/gRPC. The connection is established lazily by grpc-go; dial errors
+// surface on the first ExportLogs call.
+func NewGRPCExporter(endpoint string) (*GRPCExporter, error) {
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("otel-log-sender: dialing gRPC endpoint %q: %w", endpoint, err)
+	}
+
+	return &GRPCExporter{
+		conn:   conn,
+		client: collogspb.NewLogsServiceClient(conn),
+	}, nil
+}
+
+// Close releases the underlying gRPC connection.
+func (e *GRPCExporter) Close() error {
+	return e.conn.Close()
+}
+
+func (e *GRPCExporter) ExportLogs(ctx context.Context, groups []ResourceLogsGroup) error {
+	resourceLogs := make([]*logspb.ResourceLogs, 0, len(groups))
+	for _, g := range groups {
+		scopeLogs := make([]*logspb.ScopeLogs, 0, len(g.Scopes))
+		for _, s := range g.Scopes {
+			scopeLogs = append(scopeLogs, &logspb.ScopeLogs{
+				Scope:      toPBScope(s.Scope),
+				LogRecords: toPBLogRecords(s.Records),
+			})
+		}
+		resourceLogs = append(resourceLogs, &logspb.ResourceLogs{
+			Resource:  toPBResource(g.Resource),
+			ScopeLogs: scopeLogs,
+		})
+	}
+
+	req := &collogspb.ExportLogsServiceRequest{ResourceLogs: resourceLogs}
+
+	resp, err := e.client.Export(ctx, req)
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			return &GRPCError{Code: st.Code(), Message: st.Message()}
+		}
+		return fmt.Errorf("exporting logs via gRPC: %w", err)
+	}
+
+	if ps := resp.GetPartialSuccess(); ps != nil && ps.GetRejectedLogRecords() > 0 {
+		return &PartialSuccessError{
+			RejectedLogRecords: ps.GetRejectedLogRecords(),
+			ErrorMessage:       ps.GetErrorMessage(),
+		}
+	}
+
+	return nil
+}
+
+func toPBResource(r Resource) *resourcepb.Resource {
+	return &resourcepb.Resource{
+		Attributes: toPBKeyValues(r.Attributes),
+	}
+}
+
+func toPBScope(s Scope) *commonpb.InstrumentationScope {
+	return &commonpb.InstrumentationScope{
+		Name:    s.Name,
+		Version: s.Version,
+	}
+}
+
+func toPBLogRecords(records []*LogRecord) []*logspb.LogRecord {
+	pbRecords := make([]*logspb.LogRecord, 0, len(records))
+	for _, r := range records {
+		traceID, _ := hex.DecodeString(r.TraceID)
+		spanID, _ := hex.DecodeString(r.SpanID)
+		pbRecords = append(pbRecords, &logspb.LogRecord{
+			TimeUnixNano:   r.TimeUnixNano,
+			SeverityText:   r.SeverityText,
+			SeverityNumber: logspb.SeverityNumber(r.SeverityNumber),
+			Body:           toPBAttributeValue(r.Body),
+			Attributes:     toPBKeyValues(r.Attributes),
+			TraceId:        traceID,
+			SpanId:         spanID,
+			Flags:          r.Flags,
+		})
+	}
+	return pbRecords
+}
+
+func toPBKeyValues(kvs []KeyValue) []*commonpb.KeyValue {
+	pbKVs := make([]*commonpb.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		pbKVs = append(pbKVs, &commonpb.KeyValue{
+			Key:   kv.Key,
+			Value: toPBAttributeValue(kv.Value),
+		})
+	}
+	return pbKVs
+}
+
+func toPBAttributeValue(v AttributeValue) *commonpb.AnyValue {
+	switch {
+	case v.StringValue != nil:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: *v.StringValue}}
+	case v.BoolValue != nil:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: *v.BoolValue}}
+	case v.IntValue != nil:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: *v.IntValue}}
+	case v.DoubleValue != nil:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: *v.DoubleValue}}
+	case v.ArrayValue != nil:
+		values := make([]*commonpb.AnyValue, 0, len(v.ArrayValue.Values))
+		for _, av := range v.ArrayValue.Values {
+			values = append(values, toPBAttributeValue(av))
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}}
+	case v.KvlistValue != nil:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{KvlistValue: &commonpb.KeyValueList{Values: toPBKeyValues(v.KvlistValue.Values)}}}
+	case v.BytesValue != nil:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v.BytesValue}}
+	default:
+		return &commonpb.AnyValue{}
+	}
+}
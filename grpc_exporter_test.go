@@ -0,0 +1,67 @@
+package main
+
+import "testing"
+
+func TestToPBAttributeValueKinds(t *testing.T) {
+	s := strAttr("hi")
+	if got := toPBAttributeValue(s); got.GetStringValue() != "hi" {
+		t.Fatalf("string: got %v", got)
+	}
+
+	b := AttributeValue{BoolValue: boolPtr(true)}
+	if got := toPBAttributeValue(b); !got.GetBoolValue() {
+		t.Fatalf("bool: got %v", got)
+	}
+
+	i := int64(42)
+	iv := AttributeValue{IntValue: &i}
+	if got := toPBAttributeValue(iv); got.GetIntValue() != 42 {
+		t.Fatalf("int: got %v", got)
+	}
+
+	nested := AttributeValue{ArrayValue: &ArrayValue{Values: []AttributeValue{strAttr("a"), strAttr("b")}}}
+	got := toPBAttributeValue(nested)
+	if len(got.GetArrayValue().GetValues()) != 2 || got.GetArrayValue().GetValues()[1].GetStringValue() != "b" {
+		t.Fatalf("array: got %v", got)
+	}
+}
+
+func TestToPBLogRecordsDecodesTraceAndSpanIDs(t *testing.T) {
+	records := []*LogRecord{{
+		TimeUnixNano:   1,
+		SeverityText:   "INFO",
+		SeverityNumber: 9,
+		Body:           strAttr("hello"),
+		TraceID:        "0102030405060708090a0b0c0d0e0f10",
+		SpanID:         "0102030405060708",
+		Flags:          1,
+	}}
+
+	pb := toPBLogRecords(records)
+	if len(pb) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(pb))
+	}
+	if len(pb[0].TraceId) != 16 {
+		t.Fatalf("expected a 16-byte trace ID, got %d bytes", len(pb[0].TraceId))
+	}
+	if len(pb[0].SpanId) != 8 {
+		t.Fatalf("expected an 8-byte span ID, got %d bytes", len(pb[0].SpanId))
+	}
+	if pb[0].Flags != 1 {
+		t.Fatalf("expected flags to carry over, got %d", pb[0].Flags)
+	}
+}
+
+func TestToPBResourceAndScope(t *testing.T) {
+	resource := Resource{Attributes: []KeyValue{{Key: "service.name", Value: strAttr("svc")}}}
+	pbResource := toPBResource(resource)
+	if len(pbResource.Attributes) != 1 || pbResource.Attributes[0].Key != "service.name" {
+		t.Fatalf("unexpected resource conversion: %+v", pbResource)
+	}
+
+	scope := Scope{Name: "my-scope", Version: "1.2.3"}
+	pbScope := toPBScope(scope)
+	if pbScope.Name != "my-scope" || pbScope.Version != "1.2.3" {
+		t.Fatalf("unexpected scope conversion: %+v", pbScope)
+	}
+}
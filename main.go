@@ -1,12 +1,13 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"log"
-	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // A collection of ScopeLogs from a Resource.
@@ -96,38 +97,170 @@ type LogRecord struct {
 	// Attribute keys MUST be unique (it is not allowed to have more than one
 	// attribute with the same key).
 	Attributes []KeyValue `json:"attributes,omitempty"`
+	// TraceID is the hex-encoded 16-byte ID of the trace this log record is
+	// part of, for correlation with spans. [Optional].
+	TraceID string `json:"traceId,omitempty"`
+	// SpanID is the hex-encoded 8-byte ID of the span this log record is
+	// part of, for correlation with spans. [Optional].
+	SpanID string `json:"spanId,omitempty"`
+	// Flags is a bit field; the 8 least significant bits are the W3C trace
+	// flags as defined in https://www.w3.org/TR/trace-context/. [Optional].
+	Flags uint32 `json:"flags,omitempty"`
 }
 
 type LogSender struct {
-	client    *http.Client
-	endpoint  string
-	batchSize int
-	logQueue  chan LogRecord
+	exporter      Exporter
+	batchSize     int
+	logQueue      chan queuedRecord
+	resource      Resource
+	scope         Scope
+	metrics       *senderMetrics
+	exportTimeout time.Duration
 }
 
-func NewLogSender(endpoint string, batchSize int) *LogSender {
-	return &LogSender{
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
-		endpoint:  endpoint,
-		batchSize: batchSize,
-		logQueue:  make(chan LogRecord, 1000),
+// queuedRecord pairs a LogRecord with the resource and scope it was logged
+// under, so a batch can be regrouped by (Resource, Scope) identity in
+// sendBatch instead of assuming every record in the queue shares one pair.
+type queuedRecord struct {
+	record   LogRecord
+	resource Resource
+	scope    Scope
+}
+
+// Option customizes a LogSender built by NewLogSender.
+type Option func(*logSenderConfig)
+
+type logSenderConfig struct {
+	retryConfig   RetryConfig
+	onDrop        OnDrop
+	resource      *Resource
+	detectors     []Detector
+	scope         Scope
+	meterProvider metric.MeterProvider
+}
+
+// WithRetryConfig overrides the default exponential backoff settings used
+// to retry failed exports.
+func WithRetryConfig(cfg RetryConfig) Option {
+	return func(c *logSenderConfig) {
+		c.retryConfig = cfg
+	}
+}
+
+// WithOnDrop registers a callback invoked with batches that could not be
+// delivered after retries are exhausted or that failed permanently, so
+// callers can persist them (e.g. to a disk-backed dead-letter queue).
+func WithOnDrop(onDrop OnDrop) Option {
+	return func(c *logSenderConfig) {
+		c.onDrop = onDrop
+	}
+}
+
+// WithResource sets the Resource attached to every exported batch verbatim,
+// bypassing auto-detection entirely.
+func WithResource(resource Resource) Option {
+	return func(c *logSenderConfig) {
+		c.resource = &resource
+	}
+}
+
+// WithResourceDetectors overrides the detectors used to build the Resource
+// when WithResource isn't given. Defaults to DefaultDetectors.
+func WithResourceDetectors(detectors ...Detector) Option {
+	return func(c *logSenderConfig) {
+		c.detectors = detectors
+	}
+}
+
+// WithScope sets the instrumentation scope attached to every exported
+// batch. Defaults to Scope{Name: "otel-log-sender"}.
+func WithScope(scope Scope) Option {
+	return func(c *logSenderConfig) {
+		c.scope = scope
+	}
+}
+
+// WithMeterProvider sets the metric.MeterProvider used to report the
+// LogSender's own health metrics (queue depth, drops, export outcomes).
+// Defaults to otel.GetMeterProvider().
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return func(c *logSenderConfig) {
+		c.meterProvider = meterProvider
+	}
+}
+
+// NewLogSender builds a LogSender that batches logs and ships them to
+// endpoint using the given protocol. The protocol mirrors
+// OTEL_EXPORTER_OTLP_PROTOCOL: ProtocolHTTPJSON, ProtocolHTTPProtobuf, or
+// ProtocolGRPC. An empty protocol defaults to ProtocolHTTPJSON.
+//
+// Unless WithResource is given, the resource is built by running
+// DefaultDetectors (or the detectors passed to WithResourceDetectors).
+func NewLogSender(endpoint string, batchSize int, protocol Protocol, opts ...Option) (*LogSender, error) {
+	exporter, err := newExporter(protocol, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := logSenderConfig{
+		retryConfig:   DefaultRetryConfig(),
+		scope:         Scope{Name: "otel-log-sender"},
+		meterProvider: otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
 	}
+
+	resource := cfg.resource
+	if resource == nil {
+		detectors := cfg.detectors
+		if detectors == nil {
+			detectors = DefaultDetectors()
+		}
+		detected := DetectResource(detectors...)
+		resource = &detected
+	}
+
+	logQueue := make(chan queuedRecord, 1000)
+	metrics, err := newSenderMetrics(cfg.meterProvider, func() int64 { return int64(len(logQueue)) })
+	if err != nil {
+		return nil, err
+	}
+
+	exportTimeout := cfg.retryConfig.MaxElapsedTime
+	if exportTimeout < sendBatchTimeoutFloor {
+		exportTimeout = sendBatchTimeoutFloor
+	}
+
+	return &LogSender{
+		exporter:      newRetryingExporter(exporter, cfg.retryConfig, cfg.onDrop, metrics),
+		batchSize:     batchSize,
+		logQueue:      logQueue,
+		resource:      *resource,
+		scope:         cfg.scope,
+		metrics:       metrics,
+		exportTimeout: exportTimeout,
+	}, nil
 }
 
 func (ls *LogSender) Start() {
 	go ls.processQueue()
 }
 
-func (ls *LogSender) Log(severityText string, severityNumber int, message string, attrs map[string]interface{}) {
-	body := AttributeValue{StringValue: &message}
+func (ls *LogSender) Log(severityText string, severityNumber int, message string, attrs map[string]interface{}, opts ...LogOption) {
+	entry := buildLogRecord(severityText, severityNumber, message, attrs)
+	for _, opt := range opts {
+		opt(&entry)
+	}
+	ls.enqueue(entry, ls.resource, ls.scope)
+}
 
+func buildLogRecord(severityText string, severityNumber int, message string, attrs map[string]interface{}) LogRecord {
 	entry := LogRecord{
 		TimeUnixNano:   uint64(time.Now().UnixNano()),
 		SeverityText:   severityText,
 		SeverityNumber: severityNumber,
-		Body:           body,
+		Body:           AttributeValue{StringValue: &message},
 	}
 
 	if attrs != nil {
@@ -140,10 +273,20 @@ func (ls *LogSender) Log(severityText string, severityNumber int, message string
 		}
 	}
 
+	return entry
+}
+
+// enqueue queues a pre-built LogRecord tagged with its resource and scope,
+// dropping it if the queue is full. Used directly by callers, such as the
+// slog handler, that build their own LogRecord instead of going through Log.
+func (ls *LogSender) enqueue(entry LogRecord, resource Resource, scope Scope) {
+	ctx := context.Background()
 	select {
-	case ls.logQueue <- entry:
+	case ls.logQueue <- queuedRecord{record: entry, resource: resource, scope: scope}:
+		ls.metrics.recordsEnqueued.Add(ctx, 1)
 	default:
 		log.Println("Log queue full, dropping log entry")
+		ls.metrics.recordDropped(ctx, dropReasonQueueFull, 1)
 	}
 }
 
@@ -167,14 +310,14 @@ func convertToAttributeValue(v interface{}) AttributeValue {
 }
 
 func (ls *LogSender) processQueue() {
-	var batch []*LogRecord
+	var batch []queuedRecord
 	ticker := time.NewTicker(3 * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case entry := <-ls.logQueue:
-			batch = append(batch, &entry)
+			batch = append(batch, entry)
 			if len(batch) >= ls.batchSize {
 				ls.sendBatch(batch)
 				batch = nil
@@ -188,96 +331,31 @@ func (ls *LogSender) processQueue() {
 	}
 }
 
-func (ls *LogSender) sendBatch(batch []*LogRecord) {
-	resource := Resource{
-		Attributes: []KeyValue{
-			{
-				Key: "service.name",
-				Value: AttributeValue{
-					StringValue: stringPtr("my-go-app"),
-				},
-			},
-			{
-				Key: "service.version",
-				Value: AttributeValue{
-					StringValue: stringPtr("1.0.0"),
-				},
-			},
-			{
-				Key: "telemetry.sdk.language",
-				Value: AttributeValue{
-					StringValue: stringPtr("go"),
-				},
-			},
-		},
-	}
-	scope := Scope{
-		Name:    "custom-logger",
-		Version: "1.0",
-	}
-	scopeLogs := []*ScopeLogs{
-		{
-			Scope:      scope,
-			LogRecords: batch,
-		},
-	}
-	payload := map[string]interface{}{
-		"resourceLogs": []ResourceLogs{
-			{
-				Resource:  resource,
-				ScopeLogs: scopeLogs,
-			},
-		},
-	}
+// sendBatchTimeoutFloor bounds how short the overall export deadline can get
+// even if a caller configures an unreasonably small RetryConfig.MaxElapsedTime,
+// leaving room for at least one real attempt plus a retry.
+const sendBatchTimeoutFloor = 15 * time.Second
 
-	// payload := map[string]interface{}{
-	// 	"resourceLogs": []map[string]interface{}{
-	// 		{
-	// 			"resource": resource,
-	// 			"scopeLogs": []map[string]interface{}{
-	// 				{
-	// 					"scope": Scope{
-	// 						Name:    "custom-logger",
-	// 						Version: "1.0",
-	// 					},
-	// 					"logRecords": batch,
-	// 				},
-	// 			},
-	// 		},
-	// 	},
-	// }
-
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		log.Printf("Error marshaling log batch: %v", err)
-		return
-	}
+func (ls *LogSender) sendBatch(batch []queuedRecord) {
+	// The export, including every retry attempt, must fit within
+	// MaxElapsedTime; a shorter fixed deadline here would cut retries off
+	// before backoff's own elapsed-time tracking ever gets a say.
+	ctx, cancel := context.WithTimeout(context.Background(), ls.exportTimeout)
+	defer cancel()
 
-	req, err := http.NewRequest("POST", ls.endpoint+"/v1/logs", bytes.NewBuffer(jsonData))
-	if err != nil {
-		log.Printf("Error creating request: %v", err)
-		return
-	}
-	req.Header.Set("Content-Type", "application/json")
+	groups := groupByResourceAndScope(batch)
+
+	start := time.Now()
+	err := ls.exporter.ExportLogs(ctx, groups)
+	ls.metrics.exportDuration.Record(ctx, float64(time.Since(start).Milliseconds()))
 
-	resp, err := ls.client.Do(req)
 	if err != nil {
-		log.Printf("Error sending logs: %v", err)
+		ls.metrics.exportFailures.Add(ctx, 1)
+		log.Printf("Error exporting logs: %v", err)
 		return
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode >= 400 {
-		var responseBody map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&responseBody); err == nil {
-			log.Printf("Error response: %+v", responseBody)
-		} else {
-			log.Printf("Failed to decode error response: %v", err)
-		}
-		log.Printf("Received status code: %d", resp.StatusCode)
-	} else {
-		log.Println("Logs successfully sent")
-	}
+	ls.metrics.batchesSent.Add(ctx, 1)
+	log.Println("Logs successfully sent")
 }
 
 func stringPtr(s string) *string {
@@ -285,7 +363,10 @@ func stringPtr(s string) *string {
 }
 
 func main() {
-	logSender := NewLogSender("http://localhost:4318", 10)
+	logSender, err := NewLogSender("http://localhost:4318", 10, ProtocolHTTPJSON)
+	if err != nil {
+		log.Fatalf("Failed to create log sender: %v", err)
+	}
 	logSender.Start()
 
 	// Example logs with different attribute types
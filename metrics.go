@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// meterName identifies the instrumentation scope under which LogSender
+// reports its own metrics, distinct from the Scope attached to exported
+// log records.
+const meterName = "github.com/IronCore864/otel-log-sender"
+
+// senderMetrics holds the instruments LogSender uses to report its own
+// health: how many records are enqueued and dropped, how batches fare on
+// export, and how deep the queue is running.
+type senderMetrics struct {
+	recordsEnqueued metric.Int64Counter
+	recordsDropped  metric.Int64Counter
+	batchesSent     metric.Int64Counter
+	exportFailures  metric.Int64Counter
+	exportDuration  metric.Float64Histogram
+}
+
+// newSenderMetrics creates the LogSender instruments on mp and registers an
+// observable gauge reporting queueDepth's current value.
+func newSenderMetrics(mp metric.MeterProvider, queueDepth func() int64) (*senderMetrics, error) {
+	meter := mp.Meter(meterName)
+
+	recordsEnqueued, err := meter.Int64Counter("logsender.records.enqueued",
+		metric.WithDescription("Number of log records accepted onto the send queue."))
+	if err != nil {
+		return nil, fmt.Errorf("otel-log-sender: creating records.enqueued counter: %w", err)
+	}
+
+	recordsDropped, err := meter.Int64Counter("logsender.records.dropped",
+		metric.WithDescription("Number of log records dropped without being exported, by reason."))
+	if err != nil {
+		return nil, fmt.Errorf("otel-log-sender: creating records.dropped counter: %w", err)
+	}
+
+	batchesSent, err := meter.Int64Counter("logsender.batches.sent",
+		metric.WithDescription("Number of batches exported successfully."))
+	if err != nil {
+		return nil, fmt.Errorf("otel-log-sender: creating batches.sent counter: %w", err)
+	}
+
+	exportFailures, err := meter.Int64Counter("logsender.export.failures",
+		metric.WithDescription("Number of batch export attempts that failed after retries were exhausted."))
+	if err != nil {
+		return nil, fmt.Errorf("otel-log-sender: creating export.failures counter: %w", err)
+	}
+
+	exportDuration, err := meter.Float64Histogram("logsender.export.duration",
+		metric.WithDescription("Duration of batch export calls."),
+		metric.WithUnit("ms"))
+	if err != nil {
+		return nil, fmt.Errorf("otel-log-sender: creating export.duration histogram: %w", err)
+	}
+
+	_, err = meter.Int64ObservableGauge("logsender.queue.depth",
+		metric.WithDescription("Current number of log records waiting to be batched."),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(queueDepth())
+			return nil
+		}))
+	if err != nil {
+		return nil, fmt.Errorf("otel-log-sender: creating queue.depth gauge: %w", err)
+	}
+
+	return &senderMetrics{
+		recordsEnqueued: recordsEnqueued,
+		recordsDropped:  recordsDropped,
+		batchesSent:     batchesSent,
+		exportFailures:  exportFailures,
+		exportDuration:  exportDuration,
+	}, nil
+}
+
+// Drop reasons reported on the logsender.records.dropped counter's "reason"
+// attribute.
+const (
+	dropReasonQueueFull    = "queue_full"
+	dropReasonRejected     = "rejected"
+	dropReasonPermanentErr = "permanent_error"
+)
+
+func (m *senderMetrics) recordDropped(ctx context.Context, reason string, count int64) {
+	m.recordsDropped.Add(ctx, count, metric.WithAttributes(attribute.String("reason", reason)))
+}
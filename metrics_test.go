@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// recordingInt64Counter wraps noop.Int64Counter to total Add calls and
+// break them down by the "reason" attribute, so tests can assert on
+// senderMetrics' drop-reason wiring without a full SDK dependency.
+type recordingInt64Counter struct {
+	noop.Int64Counter
+	total    int64
+	byReason map[string]int64
+}
+
+func (c *recordingInt64Counter) Add(_ context.Context, incr int64, opts ...metric.AddOption) {
+	c.total += incr
+	attrs := metric.NewAddConfig(opts).Attributes()
+	if reason, ok := attrs.Value("reason"); ok {
+		if c.byReason == nil {
+			c.byReason = make(map[string]int64)
+		}
+		c.byReason[reason.AsString()] += incr
+	}
+}
+
+type recordingMeter struct {
+	noop.Meter
+	recordsDropped *recordingInt64Counter
+}
+
+func (m *recordingMeter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	if name == "logsender.records.dropped" {
+		return m.recordsDropped, nil
+	}
+	return noop.Int64Counter{}, nil
+}
+
+type recordingMeterProvider struct {
+	noop.MeterProvider
+	meter *recordingMeter
+}
+
+func (p *recordingMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return p.meter
+}
+
+func newRecordingMetrics(t *testing.T) (*senderMetrics, *recordingInt64Counter) {
+	t.Helper()
+	recordsDropped := &recordingInt64Counter{}
+	mp := &recordingMeterProvider{meter: &recordingMeter{recordsDropped: recordsDropped}}
+
+	metrics, err := newSenderMetrics(mp, func() int64 { return 0 })
+	if err != nil {
+		t.Fatalf("newSenderMetrics: %v", err)
+	}
+	return metrics, recordsDropped
+}
+
+func TestRecordDroppedTagsReason(t *testing.T) {
+	metrics, recordsDropped := newRecordingMetrics(t)
+
+	metrics.recordDropped(context.Background(), dropReasonQueueFull, 3)
+	metrics.recordDropped(context.Background(), dropReasonRejected, 2)
+
+	if recordsDropped.total != 5 {
+		t.Fatalf("expected 5 total drops, got %d", recordsDropped.total)
+	}
+	if recordsDropped.byReason[dropReasonQueueFull] != 3 {
+		t.Fatalf("expected 3 drops tagged %q, got %d", dropReasonQueueFull, recordsDropped.byReason[dropReasonQueueFull])
+	}
+	if recordsDropped.byReason[dropReasonRejected] != 2 {
+		t.Fatalf("expected 2 drops tagged %q, got %d", dropReasonRejected, recordsDropped.byReason[dropReasonRejected])
+	}
+}
+
+func TestRetryingExporterRecordsPermanentErrorDrop(t *testing.T) {
+	metrics, recordsDropped := newRecordingMetrics(t)
+	fake := &fakeExporter{errs: []error{&HTTPError{StatusCode: 400}}}
+
+	r := newRetryingExporter(fake, fastRetryConfig(), nil, metrics)
+	groups := []ResourceLogsGroup{{Scopes: []ScopeLogsGroup{{Records: []*LogRecord{{}, {}}}}}}
+
+	if err := r.ExportLogs(context.Background(), groups); err == nil {
+		t.Fatal("expected a permanent error")
+	}
+
+	if recordsDropped.byReason[dropReasonPermanentErr] != 2 {
+		t.Fatalf("expected 2 records dropped as %q, got %d", dropReasonPermanentErr, recordsDropped.byReason[dropReasonPermanentErr])
+	}
+}
+
+func TestRetryingExporterRecordsRejectedDrop(t *testing.T) {
+	metrics, recordsDropped := newRecordingMetrics(t)
+	fake := &fakeExporter{errs: []error{&PartialSuccessError{RejectedLogRecords: 4, ErrorMessage: "bad"}}}
+
+	r := newRetryingExporter(fake, fastRetryConfig(), nil, metrics)
+	if err := r.ExportLogs(context.Background(), nil); err != nil {
+		t.Fatalf("expected partial success to not surface as an error, got %v", err)
+	}
+
+	if recordsDropped.byReason[dropReasonRejected] != 4 {
+		t.Fatalf("expected 4 records dropped as %q, got %d", dropReasonRejected, recordsDropped.byReason[dropReasonRejected])
+	}
+}
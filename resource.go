@@ -0,0 +1,125 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Detector produces resource attributes to be merged into a LogSender's
+// Resource, mirroring the OTel SDK's resource detectors.
+type Detector interface {
+	Detect() []KeyValue
+}
+
+// DetectorFunc adapts a plain function to a Detector.
+type DetectorFunc func() []KeyValue
+
+func (f DetectorFunc) Detect() []KeyValue { return f() }
+
+// DefaultDetectors returns the detectors NewLogSender runs unless
+// WithResource or WithResourceDetectors is supplied: process, host, and OS
+// attributes, then environment variables. EnvDetector runs last so that
+// OTEL_SERVICE_NAME/OTEL_RESOURCE_ATTRIBUTES override auto-detected values
+// for the same key, rather than the other way around.
+func DefaultDetectors() []Detector {
+	return []Detector{
+		ProcessDetector(),
+		HostDetector(),
+		OSDetector(),
+		EnvDetector(),
+	}
+}
+
+// DetectResource runs detectors in order and merges their attributes into a
+// single Resource. When two detectors report the same key, the later
+// detector wins.
+func DetectResource(detectors ...Detector) Resource {
+	attrs := make(map[string]AttributeValue)
+	var order []string
+
+	for _, d := range detectors {
+		for _, kv := range d.Detect() {
+			if _, ok := attrs[kv.Key]; !ok {
+				order = append(order, kv.Key)
+			}
+			attrs[kv.Key] = kv.Value
+		}
+	}
+
+	resource := Resource{Attributes: make([]KeyValue, 0, len(order))}
+	for _, key := range order {
+		resource.Attributes = append(resource.Attributes, KeyValue{Key: key, Value: attrs[key]})
+	}
+	return resource
+}
+
+// EnvDetector reads OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES (a
+// comma-separated list of key=value pairs) from the environment, the same
+// variables the OTel SDK's environment resource detector reads.
+func EnvDetector() Detector {
+	return DetectorFunc(func() []KeyValue {
+		var attrs []KeyValue
+
+		for _, kv := range strings.Split(os.Getenv("OTEL_RESOURCE_ATTRIBUTES"), ",") {
+			kv = strings.TrimSpace(kv)
+			if kv == "" {
+				continue
+			}
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			attrs = append(attrs, KeyValue{
+				Key:   strings.TrimSpace(parts[0]),
+				Value: AttributeValue{StringValue: stringPtr(strings.TrimSpace(parts[1]))},
+			})
+		}
+
+		if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+			attrs = append(attrs, KeyValue{
+				Key:   "service.name",
+				Value: AttributeValue{StringValue: stringPtr(name)},
+			})
+		}
+
+		return attrs
+	})
+}
+
+// ProcessDetector reports process.pid, process.executable.name,
+// process.runtime.name, and process.runtime.version.
+func ProcessDetector() Detector {
+	return DetectorFunc(func() []KeyValue {
+		pid := int64(os.Getpid())
+		return []KeyValue{
+			{Key: "process.pid", Value: AttributeValue{IntValue: &pid}},
+			{Key: "process.executable.name", Value: AttributeValue{StringValue: stringPtr(filepath.Base(os.Args[0]))}},
+			{Key: "process.runtime.name", Value: AttributeValue{StringValue: stringPtr("go")}},
+			{Key: "process.runtime.version", Value: AttributeValue{StringValue: stringPtr(runtime.Version())}},
+		}
+	})
+}
+
+// HostDetector reports host.name and host.arch.
+func HostDetector() Detector {
+	return DetectorFunc(func() []KeyValue {
+		var attrs []KeyValue
+		if hostname, err := os.Hostname(); err == nil {
+			attrs = append(attrs, KeyValue{Key: "host.name", Value: AttributeValue{StringValue: stringPtr(hostname)}})
+		}
+		attrs = append(attrs, KeyValue{Key: "host.arch", Value: AttributeValue{StringValue: stringPtr(runtime.GOARCH)}})
+		return attrs
+	})
+}
+
+// OSDetector reports os.type, using the semantic conventions' platform
+// identifiers (e.g. "linux", "darwin", "windows").
+func OSDetector() Detector {
+	return DetectorFunc(func() []KeyValue {
+		return []KeyValue{
+			{Key: "os.type", Value: AttributeValue{StringValue: stringPtr(runtime.GOOS)}},
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestDefaultDetectorsEnvOverridesAutoDetected(t *testing.T) {
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "host.name=my-custom-host")
+
+	resource := DetectResource(DefaultDetectors()...)
+
+	var hostName string
+	for _, kv := range resource.Attributes {
+		if kv.Key == "host.name" {
+			hostName = *kv.Value.StringValue
+		}
+	}
+
+	if hostName != "my-custom-host" {
+		t.Fatalf("expected OTEL_RESOURCE_ATTRIBUTES to override the auto-detected host.name, got %q", hostName)
+	}
+}
+
+func TestDetectResourceLaterDetectorWins(t *testing.T) {
+	first := DetectorFunc(func() []KeyValue {
+		return []KeyValue{{Key: "k", Value: AttributeValue{StringValue: strPtr("first")}}}
+	})
+	second := DetectorFunc(func() []KeyValue {
+		return []KeyValue{{Key: "k", Value: AttributeValue{StringValue: strPtr("second")}}}
+	})
+
+	resource := DetectResource(first, second)
+
+	if len(resource.Attributes) != 1 || *resource.Attributes[0].Value.StringValue != "second" {
+		t.Fatalf("expected the later detector to win, got %+v", resource.Attributes)
+	}
+}
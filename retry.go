@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// RetryConfig configures the exponential backoff retry layer wrapping an
+// Exporter.
+type RetryConfig struct {
+	InitialInterval     time.Duration
+	Multiplier          float64
+	MaxInterval         time.Duration
+	MaxElapsedTime      time.Duration
+	RandomizationFactor float64
+
+	// AttemptTimeout bounds a single export call, independent of the
+	// overall MaxElapsedTime budget. It keeps one stuck attempt (e.g. a
+	// collector that accepts a connection but never responds) from
+	// silently consuming the whole retry budget.
+	AttemptTimeout time.Duration
+}
+
+// DefaultRetryConfig returns the retry settings used when no RetryConfig is
+// supplied via WithRetryConfig.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		InitialInterval:     5 * time.Second,
+		Multiplier:          1.5,
+		MaxInterval:         30 * time.Second,
+		MaxElapsedTime:      time.Minute,
+		RandomizationFactor: 0.5,
+		AttemptTimeout:      10 * time.Second,
+	}
+}
+
+// OnDrop is invoked with a batch that could not be delivered, either
+// because the backoff gave up or because the failure was permanent. It
+// lets callers integrate their own dead-letter handling, such as disk
+// buffering, instead of silently losing the batch.
+type OnDrop func(groups []ResourceLogsGroup, err error)
+
+// retryAfterBackOff wraps a BackOff and lets a single NextBackOff call be
+// overridden, so a server-provided Retry-After delay can preempt the
+// computed backoff interval for that attempt only.
+type retryAfterBackOff struct {
+	backoff.BackOff
+	override time.Duration
+}
+
+func (b *retryAfterBackOff) NextBackOff() time.Duration {
+	if b.override > 0 {
+		d := b.override
+		b.override = 0
+		return d
+	}
+	return b.BackOff.NextBackOff()
+}
+
+// retryingExporter wraps an Exporter with exponential backoff, retrying
+// network errors and HTTP 429/502/503/504 responses while treating other
+// 4xx responses as permanent. OTLP partial-success responses are logged
+// and never retried, since the rejected records can't be resent without
+// duplicating the accepted ones.
+type retryingExporter struct {
+	exporter Exporter
+	config   RetryConfig
+	onDrop   OnDrop
+	metrics  *senderMetrics
+}
+
+func newRetryingExporter(exporter Exporter, config RetryConfig, onDrop OnDrop, metrics *senderMetrics) *retryingExporter {
+	return &retryingExporter{
+		exporter: exporter,
+		config:   config,
+		onDrop:   onDrop,
+		metrics:  metrics,
+	}
+}
+
+func (r *retryingExporter) ExportLogs(ctx context.Context, groups []ResourceLogsGroup) error {
+	expBackOff := backoff.NewExponentialBackOff()
+	expBackOff.InitialInterval = r.config.InitialInterval
+	expBackOff.Multiplier = r.config.Multiplier
+	expBackOff.MaxInterval = r.config.MaxInterval
+	expBackOff.MaxElapsedTime = r.config.MaxElapsedTime
+	expBackOff.RandomizationFactor = r.config.RandomizationFactor
+
+	rb := &retryAfterBackOff{BackOff: expBackOff}
+
+	operation := func() error {
+		attemptCtx := ctx
+		if r.config.AttemptTimeout > 0 {
+			var cancel context.CancelFunc
+			attemptCtx, cancel = context.WithTimeout(ctx, r.config.AttemptTimeout)
+			defer cancel()
+		}
+
+		err := r.exporter.ExportLogs(attemptCtx, groups)
+		if err == nil {
+			return nil
+		}
+
+		var partial *PartialSuccessError
+		if errors.As(err, &partial) {
+			log.Printf("Partial success exporting logs: %v", partial)
+			r.metrics.recordDropped(ctx, dropReasonRejected, partial.RejectedLogRecords)
+			return nil
+		}
+
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) {
+			if !httpErr.Retryable() {
+				return backoff.Permanent(err)
+			}
+			rb.override = httpErr.RetryAfter
+		}
+
+		var grpcErr *GRPCError
+		if errors.As(err, &grpcErr) && !grpcErr.Retryable() {
+			return backoff.Permanent(err)
+		}
+
+		return err
+	}
+
+	err := backoff.Retry(operation, backoff.WithContext(rb, ctx))
+	if err != nil {
+		r.metrics.recordDropped(ctx, dropReasonPermanentErr, countRecords(groups))
+		if r.onDrop != nil {
+			r.onDrop(groups, err)
+		}
+	}
+	return err
+}
+
+// countRecords totals the log records across every resource and scope in
+// groups, for sizing the records.dropped metric.
+func countRecords(groups []ResourceLogsGroup) int64 {
+	var n int64
+	for _, g := range groups {
+		for _, s := range g.Scopes {
+			n += int64(len(s.Records))
+		}
+	}
+	return n
+}
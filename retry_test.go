@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/metric/noop"
+	"google.golang.org/grpc/codes"
+)
+
+// fakeExporter replays a scripted sequence of errors (nil meaning success)
+// and records how many times ExportLogs was called.
+type fakeExporter struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeExporter) ExportLogs(ctx context.Context, groups []ResourceLogsGroup) error {
+	var err error
+	if f.calls < len(f.errs) {
+		err = f.errs[f.calls]
+	}
+	f.calls++
+	return err
+}
+
+func fastRetryConfig() RetryConfig {
+	return RetryConfig{
+		InitialInterval:     time.Millisecond,
+		Multiplier:          1,
+		MaxInterval:         time.Millisecond,
+		MaxElapsedTime:      time.Second,
+		RandomizationFactor: 0,
+		AttemptTimeout:      time.Second,
+	}
+}
+
+func testMetrics(t *testing.T) *senderMetrics {
+	t.Helper()
+	metrics, err := newSenderMetrics(noop.NewMeterProvider(), func() int64 { return 0 })
+	if err != nil {
+		t.Fatalf("newSenderMetrics: %v", err)
+	}
+	return metrics
+}
+
+func TestRetryingExporterRetriesRetryableHTTPError(t *testing.T) {
+	fake := &fakeExporter{errs: []error{
+		&HTTPError{StatusCode: 503},
+		&HTTPError{StatusCode: 502},
+		nil,
+	}}
+	r := newRetryingExporter(fake, fastRetryConfig(), nil, testMetrics(t))
+
+	if err := r.ExportLogs(context.Background(), nil); err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if fake.calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", fake.calls)
+	}
+}
+
+func TestRetryingExporterPermanentHTTPErrorNotRetried(t *testing.T) {
+	fake := &fakeExporter{errs: []error{&HTTPError{StatusCode: 400}}}
+
+	var dropped error
+	onDrop := func(_ []ResourceLogsGroup, err error) { dropped = err }
+
+	r := newRetryingExporter(fake, fastRetryConfig(), onDrop, testMetrics(t))
+
+	err := r.ExportLogs(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected a permanent error to be returned")
+	}
+	if fake.calls != 1 {
+		t.Fatalf("expected exactly 1 attempt for a non-retryable status, got %d", fake.calls)
+	}
+	if dropped == nil {
+		t.Fatal("expected onDrop to be invoked with the permanent error")
+	}
+}
+
+func TestRetryingExporterRetriesRetryableGRPCError(t *testing.T) {
+	fake := &fakeExporter{errs: []error{
+		&GRPCError{Code: codes.Unavailable},
+		nil,
+	}}
+	r := newRetryingExporter(fake, fastRetryConfig(), nil, testMetrics(t))
+
+	if err := r.ExportLogs(context.Background(), nil); err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if fake.calls != 2 {
+		t.Fatalf("expected 2 attempts, got %d", fake.calls)
+	}
+}
+
+func TestRetryingExporterPermanentGRPCErrorNotRetried(t *testing.T) {
+	fake := &fakeExporter{errs: []error{&GRPCError{Code: codes.InvalidArgument}}}
+	r := newRetryingExporter(fake, fastRetryConfig(), nil, testMetrics(t))
+
+	err := r.ExportLogs(context.Background(), nil)
+	if err == nil {
+		t.Fatal("expected a permanent error to be returned")
+	}
+	if fake.calls != 1 {
+		t.Fatalf("expected exactly 1 attempt for a permanent gRPC status, got %d", fake.calls)
+	}
+}
+
+func TestRetryingExporterPartialSuccessNotRetried(t *testing.T) {
+	fake := &fakeExporter{errs: []error{&PartialSuccessError{RejectedLogRecords: 2, ErrorMessage: "bad record"}}}
+	r := newRetryingExporter(fake, fastRetryConfig(), nil, testMetrics(t))
+
+	if err := r.ExportLogs(context.Background(), nil); err != nil {
+		t.Fatalf("expected partial success to not surface as an error, got %v", err)
+	}
+	if fake.calls != 1 {
+		t.Fatalf("expected exactly 1 attempt, rejected records are never resent, got %d calls", fake.calls)
+	}
+}
+
+func TestRetryingExporterContextCanceledIsNotRetriedForever(t *testing.T) {
+	fake := &fakeExporter{errs: []error{errors.New("network error"), errors.New("network error"), errors.New("network error")}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := newRetryingExporter(fake, fastRetryConfig(), nil, testMetrics(t))
+	if err := r.ExportLogs(ctx, nil); err == nil {
+		t.Fatal("expected an error once the context is already canceled")
+	}
+}
+
+func TestRetryAfterBackOffOverridesNextIntervalOnce(t *testing.T) {
+	base := &constantBackOff{interval: time.Hour}
+	rb := &retryAfterBackOff{BackOff: base, override: 5 * time.Millisecond}
+
+	if got := rb.NextBackOff(); got != 5*time.Millisecond {
+		t.Fatalf("expected the Retry-After override to win on the first call, got %v", got)
+	}
+	if got := rb.NextBackOff(); got != time.Hour {
+		t.Fatalf("expected the override to only apply once, got %v", got)
+	}
+}
+
+// constantBackOff is a minimal backoff.BackOff stub for exercising
+// retryAfterBackOff in isolation.
+type constantBackOff struct {
+	interval time.Duration
+}
+
+func (b *constantBackOff) NextBackOff() time.Duration { return b.interval }
+func (b *constantBackOff) Reset()                     {}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// SlogHandler adapts a LogSender to the standard library's log/slog.Handler
+// interface, so applications can keep using idiomatic slog logging while
+// shipping OTLP logs.
+type SlogHandler struct {
+	sender *LogSender
+	attrs  []KeyValue
+	prefix string // dot-joined group names opened via WithGroup
+}
+
+// NewSlogHandler returns a slog.Handler that forwards records to sender.
+func NewSlogHandler(sender *LogSender) *SlogHandler {
+	return &SlogHandler{sender: sender}
+}
+
+func (h *SlogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	attrs := make([]KeyValue, len(h.attrs), len(h.attrs)+record.NumAttrs())
+	copy(attrs, h.attrs)
+
+	record.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, h.prefixedKeyValue(a))
+		return true
+	})
+
+	message := record.Message
+	entry := LogRecord{
+		TimeUnixNano:   uint64(record.Time.UnixNano()),
+		SeverityText:   record.Level.String(),
+		SeverityNumber: slogLevelToSeverityNumber(record.Level),
+		Body:           AttributeValue{StringValue: &message},
+		Attributes:     attrs,
+	}
+	populateTraceContext(&entry, ctx)
+
+	h.sender.enqueue(entry, h.sender.resource, h.sender.scope)
+
+	return nil
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newAttrs := make([]KeyValue, len(h.attrs), len(h.attrs)+len(attrs))
+	copy(newAttrs, h.attrs)
+	for _, a := range attrs {
+		newAttrs = append(newAttrs, h.prefixedKeyValue(a))
+	}
+	return &SlogHandler{sender: h.sender, attrs: newAttrs, prefix: h.prefix}
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	prefix := name
+	if h.prefix != "" {
+		prefix = h.prefix + "." + name
+	}
+	return &SlogHandler{sender: h.sender, attrs: h.attrs, prefix: prefix}
+}
+
+// prefixedKeyValue converts a slog.Attr and, if a group is currently open via
+// WithGroup, namespaces its key with the dot-joined group prefix.
+func (h *SlogHandler) prefixedKeyValue(a slog.Attr) KeyValue {
+	kv := slogAttrToKeyValue(a)
+	if h.prefix != "" {
+		kv.Key = h.prefix + "." + kv.Key
+	}
+	return kv
+}
+
+func slogLevelToSeverityNumber(level slog.Level) int {
+	switch level {
+	case slog.LevelDebug:
+		return 5
+	case slog.LevelInfo:
+		return 9
+	case slog.LevelWarn:
+		return 13
+	case slog.LevelError:
+		return 17
+	default:
+		switch {
+		case level < slog.LevelInfo:
+			return 5
+		case level < slog.LevelWarn:
+			return 9
+		case level < slog.LevelError:
+			return 13
+		default:
+			return 17
+		}
+	}
+}
+
+func slogAttrToKeyValue(a slog.Attr) KeyValue {
+	// Per the slog.Handler contract, Attr values must be resolved before
+	// use; otherwise a slog.LogValuer (e.g. lazy or redacted fields) would
+	// convert to its unexported wrapper instead of its intended value.
+	return KeyValue{Key: a.Key, Value: convertSlogValue(a.Value.Resolve())}
+}
+
+// convertSlogValue converts a slog.Value to the OTLP attribute value format,
+// producing a nested KvlistValue for slog.Group-valued attributes.
+func convertSlogValue(v slog.Value) AttributeValue {
+	switch v.Kind() {
+	case slog.KindString:
+		s := v.String()
+		return AttributeValue{StringValue: &s}
+	case slog.KindBool:
+		b := v.Bool()
+		return AttributeValue{BoolValue: &b}
+	case slog.KindInt64:
+		i := v.Int64()
+		return AttributeValue{IntValue: &i}
+	case slog.KindUint64:
+		i := int64(v.Uint64())
+		return AttributeValue{IntValue: &i}
+	case slog.KindFloat64:
+		f := v.Float64()
+		return AttributeValue{DoubleValue: &f}
+	case slog.KindDuration:
+		s := v.Duration().String()
+		return AttributeValue{StringValue: &s}
+	case slog.KindTime:
+		s := v.Time().Format(time.RFC3339Nano)
+		return AttributeValue{StringValue: &s}
+	case slog.KindGroup:
+		values := make([]KeyValue, 0, len(v.Group()))
+		for _, ga := range v.Group() {
+			values = append(values, slogAttrToKeyValue(ga))
+		}
+		return AttributeValue{KvlistValue: &KvlistValue{Values: values}}
+	default:
+		s := fmt.Sprintf("%v", v.Any())
+		return AttributeValue{StringValue: &s}
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestConvertSlogValueKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		val  slog.Value
+		want AttributeValue
+	}{
+		{"string", slog.StringValue("hi"), AttributeValue{StringValue: strPtr("hi")}},
+		{"bool", slog.BoolValue(true), AttributeValue{BoolValue: boolPtr(true)}},
+		{"int64", slog.Int64Value(42), AttributeValue{IntValue: int64Ptr(42)}},
+		{"uint64", slog.Uint64Value(7), AttributeValue{IntValue: int64Ptr(7)}},
+		{"float64", slog.Float64Value(3.5), AttributeValue{DoubleValue: float64Ptr(3.5)}},
+		{"duration", slog.DurationValue(2 * time.Second), AttributeValue{StringValue: strPtr("2s")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertSlogValue(tt.val)
+			if !attributeValueEqual(got, tt.want) {
+				t.Fatalf("convertSlogValue(%v) = %+v, want %+v", tt.val, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertSlogValueGroupNestsAsKvlist(t *testing.T) {
+	group := slog.GroupValue(slog.String("a", "1"), slog.Int("b", 2))
+	got := convertSlogValue(group)
+
+	if got.KvlistValue == nil || len(got.KvlistValue.Values) != 2 {
+		t.Fatalf("expected a 2-element KvlistValue, got %+v", got)
+	}
+	if got.KvlistValue.Values[0].Key != "a" || *got.KvlistValue.Values[0].Value.StringValue != "1" {
+		t.Fatalf("unexpected first group member: %+v", got.KvlistValue.Values[0])
+	}
+}
+
+// redactedValuer implements slog.LogValuer so Handle must resolve it before
+// conversion instead of falling through to the %v default.
+type redactedValuer struct{}
+
+func (redactedValuer) LogValue() slog.Value {
+	return slog.StringValue("resolved-secret")
+}
+
+func TestSlogAttrToKeyValueResolvesLogValuer(t *testing.T) {
+	a := slog.Any("secret", redactedValuer{})
+	kv := slogAttrToKeyValue(a)
+
+	if kv.Value.StringValue == nil || *kv.Value.StringValue != "resolved-secret" {
+		t.Fatalf("expected the LogValuer to be resolved before conversion, got %+v", kv.Value)
+	}
+}
+
+func TestSlogHandlerWithGroupPrefixesKeys(t *testing.T) {
+	sender, err := NewLogSender("http://localhost:0", 10, ProtocolHTTPJSON)
+	if err != nil {
+		t.Fatalf("NewLogSender: %v", err)
+	}
+
+	var handler slog.Handler = NewSlogHandler(sender)
+	handler = handler.WithGroup("request")
+	handler = handler.WithAttrs([]slog.Attr{slog.String("method", "GET")})
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "handled", 0)
+	record.AddAttrs(slog.String("status", "ok"))
+
+	if err := handler.Handle(context.Background(), record); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	select {
+	case queued := <-sender.logQueue:
+		keys := make(map[string]string, len(queued.record.Attributes))
+		for _, kv := range queued.record.Attributes {
+			keys[kv.Key] = *kv.Value.StringValue
+		}
+		if keys["request.method"] != "GET" {
+			t.Fatalf("expected WithAttrs key to carry the group prefix, got %+v", keys)
+		}
+		if keys["request.status"] != "ok" {
+			t.Fatalf("expected the record's own attr to carry the group prefix too, got %+v", keys)
+		}
+	default:
+		t.Fatal("expected a record on the log queue")
+	}
+}
+
+func TestSlogHandlerPopulatesTraceContextFromCtx(t *testing.T) {
+	sender, err := NewLogSender("http://localhost:0", 10, ProtocolHTTPJSON)
+	if err != nil {
+		t.Fatalf("NewLogSender: %v", err)
+	}
+
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	handler := NewSlogHandler(sender)
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "handled", 0)
+
+	if err := handler.Handle(ctx, record); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	select {
+	case queued := <-sender.logQueue:
+		if queued.record.TraceID != "0102030405060708090a0b0c0d0e0f10" {
+			t.Fatalf("expected TraceID from ctx, got %q", queued.record.TraceID)
+		}
+		if queued.record.SpanID != "0102030405060708" {
+			t.Fatalf("expected SpanID from ctx, got %q", queued.record.SpanID)
+		}
+		if queued.record.Flags != uint32(trace.FlagsSampled) {
+			t.Fatalf("expected Flags from ctx, got %d", queued.record.Flags)
+		}
+	default:
+		t.Fatal("expected a record on the log queue")
+	}
+}
+
+func attributeValueEqual(a, b AttributeValue) bool {
+	switch {
+	case a.StringValue != nil || b.StringValue != nil:
+		return a.StringValue != nil && b.StringValue != nil && *a.StringValue == *b.StringValue
+	case a.BoolValue != nil || b.BoolValue != nil:
+		return a.BoolValue != nil && b.BoolValue != nil && *a.BoolValue == *b.BoolValue
+	case a.IntValue != nil || b.IntValue != nil:
+		return a.IntValue != nil && b.IntValue != nil && *a.IntValue == *b.IntValue
+	case a.DoubleValue != nil || b.DoubleValue != nil:
+		return a.DoubleValue != nil && b.DoubleValue != nil && *a.DoubleValue == *b.DoubleValue
+	default:
+		return true
+	}
+}
+
+func boolPtr(b bool) *bool          { return &b }
+func int64Ptr(i int64) *int64       { return &i }
+func float64Ptr(f float64) *float64 { return &f }
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogOption customizes a single log entry produced by Log or LogCtx.
+type LogOption func(*LogRecord)
+
+// WithTraceContext explicitly sets the trace correlation fields on a log
+// entry, overriding any trace context derived from a context.Context via
+// LogCtx. Use this when correlation IDs come from outside the OTel SDK.
+func WithTraceContext(traceID [16]byte, spanID [8]byte, flags byte) LogOption {
+	return func(r *LogRecord) {
+		r.TraceID = hex.EncodeToString(traceID[:])
+		r.SpanID = hex.EncodeToString(spanID[:])
+		r.Flags = uint32(flags)
+	}
+}
+
+// LogCtx behaves like Log, additionally populating TraceID, SpanID, and
+// Flags from the trace.SpanContext active in ctx, if any, so logs can be
+// correlated with the span that produced them. Pass WithTraceContext to
+// override the correlation IDs explicitly.
+func (ls *LogSender) LogCtx(ctx context.Context, severityText string, severityNumber int, message string, attrs map[string]interface{}, opts ...LogOption) {
+	entry := buildLogRecord(severityText, severityNumber, message, attrs)
+	populateTraceContext(&entry, ctx)
+
+	for _, opt := range opts {
+		opt(&entry)
+	}
+
+	ls.enqueue(entry, ls.resource, ls.scope)
+}
+
+// populateTraceContext copies the trace.SpanContext active in ctx, if any,
+// onto entry's TraceID/SpanID/Flags fields.
+func populateTraceContext(entry *LogRecord, ctx context.Context) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+	entry.TraceID = hex.EncodeToString(traceID[:])
+	entry.SpanID = hex.EncodeToString(spanID[:])
+	entry.Flags = uint32(sc.TraceFlags())
+}
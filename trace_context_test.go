@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func testSpanContext(t *testing.T) trace.SpanContext {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	if err != nil {
+		t.Fatalf("TraceIDFromHex: %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("0102030405060708")
+	if err != nil {
+		t.Fatalf("SpanIDFromHex: %v", err)
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func TestLogCtxPopulatesTraceContextFromCtx(t *testing.T) {
+	sender, err := NewLogSender("http://localhost:0", 10, ProtocolHTTPJSON)
+	if err != nil {
+		t.Fatalf("NewLogSender: %v", err)
+	}
+
+	ctx := trace.ContextWithSpanContext(context.Background(), testSpanContext(t))
+	sender.LogCtx(ctx, "INFO", 9, "hello", nil)
+
+	select {
+	case queued := <-sender.logQueue:
+		if queued.record.TraceID != "0102030405060708090a0b0c0d0e0f10" {
+			t.Fatalf("expected TraceID from ctx, got %q", queued.record.TraceID)
+		}
+		if queued.record.SpanID != "0102030405060708" {
+			t.Fatalf("expected SpanID from ctx, got %q", queued.record.SpanID)
+		}
+		if queued.record.Flags != uint32(trace.FlagsSampled) {
+			t.Fatalf("expected Flags from ctx, got %d", queued.record.Flags)
+		}
+	default:
+		t.Fatal("expected a record on the log queue")
+	}
+}
+
+func TestLogCtxWithoutSpanLeavesTraceFieldsEmpty(t *testing.T) {
+	sender, err := NewLogSender("http://localhost:0", 10, ProtocolHTTPJSON)
+	if err != nil {
+		t.Fatalf("NewLogSender: %v", err)
+	}
+
+	sender.LogCtx(context.Background(), "INFO", 9, "hello", nil)
+
+	select {
+	case queued := <-sender.logQueue:
+		if queued.record.TraceID != "" || queued.record.SpanID != "" {
+			t.Fatalf("expected no trace correlation without a span in ctx, got %+v", queued.record)
+		}
+	default:
+		t.Fatal("expected a record on the log queue")
+	}
+}
+
+func TestWithTraceContextOverridesCtxDerivedCorrelation(t *testing.T) {
+	sender, err := NewLogSender("http://localhost:0", 10, ProtocolHTTPJSON)
+	if err != nil {
+		t.Fatalf("NewLogSender: %v", err)
+	}
+
+	ctx := trace.ContextWithSpanContext(context.Background(), testSpanContext(t))
+
+	var overrideTraceID [16]byte
+	var overrideSpanID [8]byte
+	copy(overrideTraceID[:], []byte{0xAA, 0xBB})
+	copy(overrideSpanID[:], []byte{0xCC, 0xDD})
+
+	sender.LogCtx(ctx, "INFO", 9, "hello", nil, WithTraceContext(overrideTraceID, overrideSpanID, 0x01))
+
+	select {
+	case queued := <-sender.logQueue:
+		if queued.record.TraceID != "aabb0000000000000000000000000000" {
+			t.Fatalf("expected the explicit override to win over ctx, got %q", queued.record.TraceID)
+		}
+		if queued.record.SpanID != "ccdd000000000000" {
+			t.Fatalf("expected the explicit override to win over ctx, got %q", queued.record.SpanID)
+		}
+		if queued.record.Flags != 1 {
+			t.Fatalf("expected the explicit flags override, got %d", queued.record.Flags)
+		}
+	default:
+		t.Fatal("expected a record on the log queue")
+	}
+}